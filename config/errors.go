@@ -0,0 +1,12 @@
+package config
+
+// Error codes passed to Handler.ReturnError. entity.ErrorResponse carries only the message,
+// so these codes are not sent to clients.
+const (
+	ErrorBadRequest   = "BAD_REQUEST"
+	ErrorInternal     = "INTERNAL_ERROR"
+	ErrorNotFound     = "NOT_FOUND"
+	ErrorForbidden    = "FORBIDDEN"
+	ErrorConflict     = "CONFLICT"
+	ErrorUnauthorized = "UNAUTHORIZED"
+)
@@ -0,0 +1,22 @@
+// Package app is the composition root: it wires the postgres repos into the use cases the
+// handler package depends on.
+package app
+
+import (
+	"database/sql"
+
+	"github.com/Avazbek-02/udevslab-lesson6/internal/repo/postgres"
+	"github.com/Avazbek-02/udevslab-lesson6/internal/usecase"
+)
+
+// NewUseCase wires the postgres repos into a usecase.UseCase. ReviewRepo is wrapped with the
+// per-request ctxcache decorator so a review fetched more than once within one HTTP request
+// hits the database once.
+func NewUseCase(db *sql.DB) usecase.UseCase {
+	return usecase.UseCase{
+		ReviewRepo:        usecase.NewCachedReviewRepo(postgres.NewReviewRepo(db)),
+		ReviewCommentRepo: postgres.NewReviewCommentRepo(db),
+		ReviewImageRepo:   postgres.NewReviewImageRepo(db),
+		IdempotencyRepo:   postgres.NewIdempotencyRepo(db),
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Avazbek-02/udevslab-lesson6/config"
+	"github.com/Avazbek-02/udevslab-lesson6/internal/entity"
+	"github.com/Avazbek-02/udevslab-lesson6/internal/usecase"
+	"github.com/Avazbek-02/udevslab-lesson6/internal/worker"
+	"github.com/gin-gonic/gin"
+)
+
+// MinIOUploader is the subset of the MinIO client used by the handler package.
+type MinIOUploader interface {
+	UploadBytes(filename string, data []byte) (objectKey string, err error)
+	PresignedURL(objectKey string) (string, error)
+}
+
+// Handler wires the HTTP layer to the use cases and external services.
+type Handler struct {
+	UseCase  usecase.UseCase
+	MinIO    MinIOUploader
+	Pipeline *worker.ImagePipeline
+}
+
+// HandleDbError writes a generic 500 error response and reports whether err was non-nil.
+func (h *Handler) HandleDbError(ctx *gin.Context, err error, msg string) bool {
+	if err == nil {
+		return false
+	}
+
+	h.ReturnError(ctx, config.ErrorInternal, msg, http.StatusInternalServerError)
+	return true
+}
+
+// ReturnError writes a structured error response with the given status code.
+func (h *Handler) ReturnError(ctx *gin.Context, code, msg string, status int) {
+	ctx.JSON(status, entity.ErrorResponse{Message: msg})
+}
+
+// RequireRole aborts the request with 403 unless the "role" header matches one of allowed.
+func (h *Handler) RequireRole(ctx *gin.Context, allowed ...string) bool {
+	role := ctx.GetHeader("role")
+	for _, r := range allowed {
+		if role == r {
+			return true
+		}
+	}
+
+	h.ReturnError(ctx, config.ErrorForbidden, "You don't have permission to perform this action", http.StatusForbidden)
+	return false
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Avazbek-02/udevslab-lesson6/config"
+	"github.com/Avazbek-02/udevslab-lesson6/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	idempotencyKeyTTL    = 24 * time.Hour
+)
+
+// idempotentResponseWriter buffers the handler's response so it can be cached verbatim.
+type idempotentResponseWriter struct {
+	gin.ResponseWriter
+	body   bytes.Buffer
+	status int
+}
+
+func (w *idempotentResponseWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *idempotentResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Idempotent opts a route into Idempotency-Key support: the first request carrying the
+// header atomically reserves it before the handler runs, a retry with the same key replays
+// the cached response once it's ready, a concurrent in-flight retry gets 425 Too Early, and
+// a key reused with a different body gets 409 Conflict. Routes that don't expect the header
+// are unaffected — register it alongside CreateReview, UpdateReview, SetReviewImage, etc.
+func (h *Handler) Idempotent() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(idempotencyKeyHeader)
+		if key == "" {
+			ctx.Next()
+			return
+		}
+
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Error reading request body", 400)
+			ctx.Abort()
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		keyHash := hashIdempotencyKey(ctx.GetHeader("sub"), key)
+		bodyHash := sha256Hex(body)
+
+		reserved, err := h.UseCase.IdempotencyRepo.Reserve(ctx, entity.IdempotencyKey{
+			KeyHash:     keyHash,
+			RequestHash: bodyHash,
+			ExpiresAt:   time.Now().Add(idempotencyKeyTTL),
+		})
+		if h.HandleDbError(ctx, err, "Error reserving idempotency key") {
+			ctx.Abort()
+			return
+		}
+
+		if !reserved {
+			h.replayIdempotencyKey(ctx, keyHash, bodyHash)
+			return
+		}
+
+		writer := &idempotentResponseWriter{ResponseWriter: ctx.Writer, status: http.StatusOK}
+		ctx.Writer = writer
+
+		ctx.Next()
+
+		// Cache the response whatever its status: an error response is still the correct
+		// reply to a retried request, and leaving the reservation incomplete would wedge the
+		// key forever (see Reserve's reclaim logic for the other half of that guarantee).
+		err = h.UseCase.IdempotencyRepo.Complete(ctx, keyHash, writer.status, writer.body.Bytes())
+		_ = err // caching is best-effort: if the write fails the key stays in flight, and retries get 425 until it expires
+	}
+}
+
+// replayIdempotencyKey handles a key that Reserve found already claimed: it either replays
+// the completed response, rejects a body mismatch with 409, or reports 425 while the request
+// that claimed it is still in flight.
+func (h *Handler) replayIdempotencyKey(ctx *gin.Context, keyHash, bodyHash string) {
+	cached, found, err := h.UseCase.IdempotencyRepo.Get(ctx, keyHash)
+	if h.HandleDbError(ctx, err, "Error checking idempotency key") {
+		ctx.Abort()
+		return
+	}
+
+	if !found {
+		// The reservation we raced against has already expired; ask the caller to retry.
+		h.ReturnError(ctx, config.ErrorConflict, "Idempotency key is being processed, retry shortly", http.StatusTooEarly)
+		ctx.Abort()
+		return
+	}
+
+	if cached.RequestHash != bodyHash {
+		h.ReturnError(ctx, config.ErrorConflict, "Idempotency key was already used with a different request", http.StatusConflict)
+		ctx.Abort()
+		return
+	}
+
+	if cached.StatusCode == 0 {
+		h.ReturnError(ctx, config.ErrorConflict, "Idempotency key is being processed, retry shortly", http.StatusTooEarly)
+		ctx.Abort()
+		return
+	}
+
+	ctx.Data(cached.StatusCode, "application/json", cached.ResponseBody)
+	ctx.Abort()
+}
+
+func hashIdempotencyKey(userID, key string) string {
+	return sha256Hex([]byte(userID + ":" + key))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -1,11 +1,16 @@
 package handler
 
 import (
+	"encoding/csv"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Avazbek-02/udevslab-lesson6/config"
 	"github.com/Avazbek-02/udevslab-lesson6/internal/entity"
+	"github.com/Avazbek-02/udevslab-lesson6/internal/worker"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -48,6 +53,7 @@ func (h *Handler) CreateReview(ctx *gin.Context) {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Review ID"
+// @Param include query string false "set to comments_count to embed the discussion size"
 // @Success 200 {object} entity.Review
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) GetReview(ctx *gin.Context) {
@@ -62,9 +68,71 @@ func (h *Handler) GetReview(ctx *gin.Context) {
 		return
 	}
 
+	if ctx.Query("include") == "comments_count" {
+		count, err := h.UseCase.ReviewCommentRepo.CountByReview(ctx, review.ID)
+		if h.HandleDbError(ctx, err, "Error counting review comments") {
+			return
+		}
+		review.CommentsCount = &count
+	}
+
 	ctx.JSON(200, review)
 }
 
+// buildReviewListFilter translates GetReviews'/GetReviewsCSV's shared query params into an
+// entity.GetListFilter. It returns false (and has already written the error response) when
+// a query param fails validation.
+func (h *Handler) buildReviewListFilter(ctx *gin.Context) (entity.GetListFilter, bool) {
+	var req entity.GetListFilter
+
+	businessID := ctx.DefaultQuery("business_id", "")
+	if _, err := uuid.Parse(businessID); err != nil && businessID != "" {
+		ctx.JSON(404, gin.H{"Error:": "Wrong format type please write UUID"})
+		return req, false
+	}
+	req.Filters = append(req.Filters,
+		entity.Filter{
+			Column: "business_id",
+			Type:   "eq",
+			Value:  businessID,
+		},
+	)
+
+	status := ctx.DefaultQuery("status", "")
+	// Unauthenticated callers never see reviews that haven't cleared moderation.
+	if status == "" && ctx.GetHeader("sub") == "" {
+		status = string(entity.ReviewStatusApproved)
+	}
+	if status != "" {
+		req.Filters = append(req.Filters, entity.Filter{Column: "status", Type: "eq", Value: status})
+	}
+
+	// parentIds narrows to any of the given businesses; grouped into one IN filter since
+	// repeated "eq" filters on the same column would be ANDed together and match nothing.
+	if parentIDs := ctx.QueryArray("parentIds"); len(parentIDs) > 0 {
+		req.Filters = append(req.Filters, entity.Filter{Column: "business_id", Type: "in", Values: parentIDs})
+	}
+	if q := ctx.Query("q"); q != "" {
+		req.Filters = append(req.Filters, entity.Filter{Column: "comment", Type: "ilike", Value: q})
+	}
+
+	req.Ranges = append(req.Ranges,
+		entity.Range{Column: "rating", From: ctx.Query("rating_min"), To: ctx.Query("rating_max")},
+		entity.Range{Column: "created_at", From: ctx.Query("created_after"), To: ctx.Query("created_before")},
+	)
+
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{Column: "created_at", Order: "desc"})
+
+	if cursor := ctx.Query("cursor"); cursor != "" {
+		req.Cursor = cursor
+	} else {
+		req.Page, _ = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	}
+	req.Limit, _ = strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	return req, true
+}
+
 // GetReviews godoc
 // @Router /review/list [get]
 // @Summary Get a list of reviews
@@ -73,45 +141,123 @@ func (h *Handler) GetReview(ctx *gin.Context) {
 // @Tags review
 // @Accept  json
 // @Produce  json
-// @Param page query number true "page"
+// @Param page query number false "page, ignored when cursor is set"
+// @Param cursor query string false "cursor returned by a previous call, for keyset pagination"
 // @Param limit query number true "limit"
 // @Param business_id query string false "business_id"
+// @Param status query string false "status"
+// @Param parentIds query []string false "parentIds"
+// @Param q query string false "free-text search over the comment"
+// @Param rating_min query number false "rating_min"
+// @Param rating_max query number false "rating_max"
+// @Param created_after query string false "created_after, RFC3339"
+// @Param created_before query string false "created_before, RFC3339"
 // @Success 200 {object} entity.ReviewList
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) GetReviews(ctx *gin.Context) {
-	var (
-		req entity.GetListFilter
-	)
+	req, ok := h.buildReviewListFilter(ctx)
+	if !ok {
+		return
+	}
 
-	page := ctx.DefaultQuery("page", "1")
-	limit := ctx.DefaultQuery("limit", "10")
-	businessID := ctx.DefaultQuery("business_id", "")
+	reviews, err := h.UseCase.ReviewRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting reviews") {
+		return
+	}
 
-	req.Page, _ = strconv.Atoi(page)
-	req.Limit, _ = strconv.Atoi(limit)
-	req.Filters = append(req.Filters,
-		entity.Filter{
-			Column: "business_id",
-			Type:   "eq",
-			Value:  businessID,
-		},
-	)
+	ctx.JSON(200, reviews)
+}
 
-	req.OrderBy = append(req.OrderBy, entity.OrderBy{
-		Column: "created_at",
-		Order:  "desc",
-	})
-	if _, err := uuid.Parse(businessID); err != nil && businessID != "" {
-		ctx.JSON(404, gin.H{"Error:": "Wrong format type please write UUID"})
+// reviewExportColumnSet is entity.ReviewExportColumns as a set, for validating ?columns=.
+var reviewExportColumnSet = func() map[string]bool {
+	set := make(map[string]bool, len(entity.ReviewExportColumns))
+	for _, c := range entity.ReviewExportColumns {
+		set[c] = true
+	}
+	return set
+}()
+
+// reviewExportRow renders r as a CSV row in exactly the given column order.
+func reviewExportRow(r entity.Review, columns []string) []string {
+	row := make([]string, len(columns))
+	for i, col := range columns {
+		switch col {
+		case "id":
+			row[i] = r.ID
+		case "user_id":
+			row[i] = r.UserID
+		case "business_id":
+			row[i] = r.BusinessID
+		case "rating":
+			row[i] = strconv.FormatFloat(r.Rating, 'f', -1, 64)
+		case "comment":
+			row[i] = r.Comment
+		case "status":
+			row[i] = string(r.Status)
+		case "created_at":
+			row[i] = r.CreatedAt.Format(time.RFC3339)
+		}
+	}
+	return row
+}
+
+// GetReviewsCSV godoc
+// @Router /review/export.csv [get]
+// @Summary Export reviews as CSV
+// @Description Stream the same filtered result set as GetReviews in CSV form
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  text/csv
+// @Param business_id query string false "business_id"
+// @Param status query string false "status"
+// @Param columns query string false "comma-separated subset/order of id,user_id,business_id,rating,comment,status,created_at"
+// @Success 200 {file} binary
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetReviewsCSV(ctx *gin.Context) {
+	req, ok := h.buildReviewListFilter(ctx)
+	if !ok {
 		return
 	}
+	req.Limit = 0 // export streams the whole filtered set, not one page
+
+	columns := entity.ReviewExportColumns
+	if raw := ctx.Query("columns"); raw != "" {
+		requested := make([]string, 0, len(reviewExportColumnSet))
+		for _, col := range strings.Split(raw, ",") {
+			col = strings.TrimSpace(col)
+			if reviewExportColumnSet[col] {
+				requested = append(requested, col)
+			}
+		}
+		if len(requested) == 0 {
+			h.ReturnError(ctx, config.ErrorBadRequest, "columns must be a subset of "+strings.Join(entity.ReviewExportColumns, ","), 400)
+			return
+		}
+		columns = requested
+	}
 
 	reviews, err := h.UseCase.ReviewRepo.GetList(ctx, req)
 	if h.HandleDbError(ctx, err, "Error getting reviews") {
 		return
 	}
 
-	ctx.JSON(200, reviews)
+	ctx.Header("Content-Disposition", `attachment; filename="reviews.csv"`)
+	ctx.Header("Content-Type", "text/csv")
+
+	w := csv.NewWriter(ctx.Writer)
+	if err := w.Write(columns); err != nil {
+		h.HandleDbError(ctx, err, "Error writing CSV header")
+		return
+	}
+
+	for _, r := range reviews.Reviews {
+		if err := w.Write(reviewExportRow(r, columns)); err != nil {
+			h.HandleDbError(ctx, err, "Error writing CSV row")
+			return
+		}
+	}
+	w.Flush()
 }
 
 // UpdateReview godoc
@@ -172,58 +318,207 @@ func (h *Handler) DeleteReview(ctx *gin.Context) {
 	})
 }
 
+// maxReviewImageBytes is the largest upload SetReviewImage will accept before rejecting it.
+const maxReviewImageBytes = 10 << 20 // 10 MiB
+
 // SetReviewImage godoc
 // @Router /review/{id}/image [post]
-// @Summary Set an image for a review
-// @Description Upload an image for a specific review
+// @Summary Upload an image for a review
+// @Description Validate and enqueue an uploaded image for async thumbnailing, scanning, and storage
 // @Tags review
 // @Accept multipart/form-data
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Review ID"
 // @Param file formData file true "Image file to upload"
-// @Success 200 {object} entity.Review
+// @Success 202 {object} entity.ReviewImageJobAccepted
 // @Failure 400 {object} entity.ErrorResponse
 // @Failure 500 {object} entity.ErrorResponse
 func (h *Handler) SetReviewImage(ctx *gin.Context) {
-	// Review ID validation
 	reviewID := ctx.Param("id")
 	if reviewID == "" {
 		h.ReturnError(ctx, config.ErrorBadRequest, "Review ID is required in path", 400)
 		return
 	}
 
-	// File retrieval
+	// Routed through CachedReviewRepo: the review fetched here is kept in the per-request cache,
+	// so a later GetSingle for it within this request does not hit the DB again.
+	if _, err := h.UseCase.ReviewRepo.GetSingle(ctx, entity.Id{ID: reviewID}); h.HandleDbError(ctx, err, "Error finding review") {
+		return
+	}
+
 	file, err := ctx.FormFile("file")
 	if err != nil {
 		h.ReturnError(ctx, config.ErrorBadRequest, "Error getting file", 400)
 		return
 	}
+	if file.Size > maxReviewImageBytes {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Image exceeds the maximum upload size", 400)
+		return
+	}
 
-	// Save the file temporarily
-	tempPath := "/tmp/" + file.Filename
-	if err := ctx.SaveUploadedFile(file, tempPath); err != nil {
-		ctx.JSON(http.StatusInternalServerError, err)
+	opened, err := file.Open()
+	if h.HandleDbError(ctx, err, "Error reading uploaded file") {
 		return
 	}
-	// Generate a unique filename
-	filename := uuid.New().String() + "-" + file.Filename
+	defer opened.Close()
 
-	// Upload to MinIO
-	minioURL, err := h.MinIO.Upload(filename, tempPath)
-	if h.HandleDbError(ctx, err, "Error uploading review image") {
+	raw, err := io.ReadAll(opened)
+	if h.HandleDbError(ctx, err, "Error reading uploaded file") {
 		return
 	}
 
-	updateReq := entity.Review{
-		ID:     reviewID,
-		Photos: minioURL,
+	if !worker.SupportedMIMETypes[http.DetectContentType(raw)] {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Uploaded file must be a JPEG, PNG, or GIF image", 400)
+		return
+	}
+
+	jobID := uuid.New().String()
+	for _, variant := range []entity.ReviewImageVariant{
+		entity.ReviewImageVariantThumbnail, entity.ReviewImageVariantMedium, entity.ReviewImageVariantLarge,
+	} {
+		err := h.UseCase.ReviewImageRepo.CreateVariant(ctx, entity.ReviewImage{
+			ReviewID: reviewID,
+			JobID:    jobID,
+			Variant:  variant,
+			Status:   entity.ReviewImageStatusUploading,
+		})
+		if h.HandleDbError(ctx, err, "Error recording review image job") {
+			return
+		}
+	}
+
+	h.Pipeline.Enqueue(worker.ImageJob{JobID: jobID, ReviewID: reviewID, Raw: raw})
+
+	ctx.JSON(http.StatusAccepted, entity.ReviewImageJobAccepted{JobID: jobID})
+}
+
+// GetReviewImages godoc
+// @Router /review/{id}/images [get]
+// @Summary List a review's images
+// @Description List every image variant uploaded for a review, with presigned GET URLs for ready ones
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Success 200 {object} entity.ReviewImageList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetReviewImages(ctx *gin.Context) {
+	images, err := h.UseCase.ReviewImageRepo.GetByReview(ctx, ctx.Param("id"))
+	if h.HandleDbError(ctx, err, "Error getting review images") {
+		return
 	}
 
-	updatedReview, err := h.UseCase.ReviewRepo.Update(ctx, updateReq)
-	if h.HandleDbError(ctx, err, "Error updating review image") {
+	for i, img := range images.Images {
+		if img.Status != entity.ReviewImageStatusReady {
+			continue
+		}
+		url, err := h.MinIO.PresignedURL(img.ObjectKey)
+		if h.HandleDbError(ctx, err, "Error generating presigned URL") {
+			return
+		}
+		images.Images[i].URL = url
+	}
+
+	ctx.JSON(200, images)
+}
+
+// transitionReview runs a moderation transition and writes the resulting review, enforcing
+// that only moderators can call it.
+func (h *Handler) transitionReview(ctx *gin.Context, to entity.ReviewStatus) {
+	if !h.RequireRole(ctx, "admin", "moderator") {
+		return
+	}
+
+	var body entity.ModerationAction
+	if err := ctx.ShouldBindJSON(&body); err != nil && err.Error() != "EOF" {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	event := entity.ReviewModerationEvent{
+		ReviewID:   ctx.Param("id"),
+		ReviewerID: ctx.GetHeader("sub"),
+		ToStatus:   to,
+		Reason:     body.Reason,
+	}
+
+	review, err := h.UseCase.ReviewRepo.Transition(ctx, event)
+	if h.HandleDbError(ctx, err, "Error transitioning review") {
+		return
+	}
+
+	ctx.JSON(200, review)
+}
+
+// ApproveReview godoc
+// @Router /review/{id}/approve [post]
+// @Summary Approve a review
+// @Description Mark a review as approved; its current status is not checked
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Param action body entity.ModerationAction false "Moderation action"
+// @Success 200 {object} entity.Review
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) ApproveReview(ctx *gin.Context) {
+	h.transitionReview(ctx, entity.ReviewStatusApproved)
+}
+
+// RejectReview godoc
+// @Router /review/{id}/reject [post]
+// @Summary Reject a review
+// @Description Mark a review as rejected
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Param action body entity.ModerationAction false "Moderation action"
+// @Success 200 {object} entity.Review
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) RejectReview(ctx *gin.Context) {
+	h.transitionReview(ctx, entity.ReviewStatusRejected)
+}
+
+// FlagReview godoc
+// @Router /review/{id}/flag [post]
+// @Summary Flag a review
+// @Description Mark a review as flagged for further moderation
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Param action body entity.ModerationAction false "Moderation action"
+// @Success 200 {object} entity.Review
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) FlagReview(ctx *gin.Context) {
+	h.transitionReview(ctx, entity.ReviewStatusFlagged)
+}
+
+// GetReviewHistory godoc
+// @Router /review/{id}/history [get]
+// @Summary Get the moderation history of a review
+// @Description List every status transition recorded for a review
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Success 200 {object} entity.ReviewModerationHistory
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetReviewHistory(ctx *gin.Context) {
+	history, err := h.UseCase.ReviewRepo.GetHistory(ctx, ctx.Param("id"))
+	if h.HandleDbError(ctx, err, "Error getting review history") {
 		return
 	}
 
-	ctx.JSON(200, updatedReview)
+	ctx.JSON(200, history)
 }
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/Avazbek-02/udevslab-lesson6/config"
+	"github.com/Avazbek-02/udevslab-lesson6/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateReviewComment godoc
+// @Router /review/{id}/comments [post]
+// @Summary Comment on a review
+// @Description Start a new top-level comment on a review's discussion thread
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Param comment body entity.ReviewComment true "Comment object"
+// @Success 200 {object} entity.ReviewComment
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) CreateReviewComment(ctx *gin.Context) {
+	var body entity.ReviewComment
+
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	body.ReviewID = ctx.Param("id")
+	body.UserID = ctx.GetHeader("sub")
+
+	comment, err := h.UseCase.ReviewCommentRepo.Create(ctx, body)
+	if h.HandleDbError(ctx, err, "Error creating review comment") {
+		return
+	}
+
+	ctx.JSON(200, comment)
+}
+
+// GetReviewComments godoc
+// @Router /review/{id}/comments [get]
+// @Summary List a review's comments
+// @Description Get the paginated discussion thread for a review
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Param page query number false "page"
+// @Param limit query number false "limit"
+// @Success 200 {object} entity.ReviewCommentList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetReviewComments(ctx *gin.Context) {
+	var req entity.GetListFilter
+
+	req.Page, _ = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	req.Limit, _ = strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	comments, err := h.UseCase.ReviewCommentRepo.GetList(ctx, ctx.Param("id"), req)
+	if h.HandleDbError(ctx, err, "Error getting review comments") {
+		return
+	}
+
+	ctx.JSON(200, comments)
+}
+
+// ReplyReviewComment godoc
+// @Router /review/{id}/comments/{comment_id}/reply [post]
+// @Summary Reply to a review comment
+// @Description Add a threaded reply underneath an existing comment
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Param comment_id path string true "Parent comment ID"
+// @Param comment body entity.ReviewComment true "Comment object"
+// @Success 200 {object} entity.ReviewComment
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) ReplyReviewComment(ctx *gin.Context) {
+	var body entity.ReviewComment
+
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+		return
+	}
+
+	body.ReviewID = ctx.Param("id")
+	body.UserID = ctx.GetHeader("sub")
+
+	comment, err := h.UseCase.ReviewCommentRepo.Reply(ctx, ctx.Param("comment_id"), body)
+	if h.HandleDbError(ctx, err, "Error replying to review comment") {
+		return
+	}
+
+	ctx.JSON(200, comment)
+}
+
+// DeleteReviewComment godoc
+// @Router /review/comments/{comment_id} [delete]
+// @Summary Delete a review comment
+// @Description Delete a comment (or reply) from a review's discussion thread
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param comment_id path string true "Comment ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) DeleteReviewComment(ctx *gin.Context) {
+	err := h.UseCase.ReviewCommentRepo.Delete(ctx, entity.Id{ID: ctx.Param("comment_id")})
+	if h.HandleDbError(ctx, err, "Error deleting review comment") {
+		return
+	}
+
+	ctx.JSON(200, entity.SuccessResponse{
+		Message: "Review comment deleted successfully",
+	})
+}
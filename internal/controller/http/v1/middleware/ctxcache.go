@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"github.com/Avazbek-02/udevslab-lesson6/pkg/ctxcache"
+	"github.com/gin-gonic/gin"
+)
+
+// CtxCache installs a fresh per-request ctxcache so repeated lookups of the same entity
+// within one HTTP request hit the database once.
+func CtxCache() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Request = ctx.Request.WithContext(ctxcache.WithCacheContext(ctx.Request.Context()))
+		ctx.Next()
+	}
+}
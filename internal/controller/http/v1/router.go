@@ -0,0 +1,31 @@
+package v1
+
+import (
+	"github.com/Avazbek-02/udevslab-lesson6/internal/controller/http/v1/handler"
+	"github.com/Avazbek-02/udevslab-lesson6/internal/controller/http/v1/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// NewReviewRoutes registers every /review endpoint on the given router group.
+func NewReviewRoutes(g *gin.RouterGroup, h *handler.Handler) {
+	review := g.Group("/review")
+	review.Use(middleware.CtxCache())
+	{
+		review.POST("", h.Idempotent(), h.CreateReview)
+		review.PUT("", h.Idempotent(), h.UpdateReview)
+		review.GET("/list", h.GetReviews)
+		review.GET("/export.csv", h.GetReviewsCSV)
+		review.GET("/:id", h.GetReview)
+		review.DELETE("/:id", h.DeleteReview)
+		review.POST("/:id/image", h.Idempotent(), h.SetReviewImage)
+		review.GET("/:id/images", h.GetReviewImages)
+		review.POST("/:id/approve", h.ApproveReview)
+		review.POST("/:id/reject", h.RejectReview)
+		review.POST("/:id/flag", h.FlagReview)
+		review.GET("/:id/history", h.GetReviewHistory)
+		review.POST("/:id/comments", h.CreateReviewComment)
+		review.GET("/:id/comments", h.GetReviewComments)
+		review.POST("/:id/comments/:comment_id/reply", h.ReplyReviewComment)
+		review.DELETE("/comments/:comment_id", h.DeleteReviewComment)
+	}
+}
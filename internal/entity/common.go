@@ -0,0 +1,53 @@
+package entity
+
+// Id is a generic wrapper used to address a single resource by its ID.
+type Id struct {
+	ID string `json:"id"`
+}
+
+// Filter describes a single predicate applied to a list query. Type "eq" and "ilike" compare
+// Column against Value; type "in" matches Column against any of Values — use "in" instead of
+// one "eq" Filter per value, since those get ANDed together and can never match.
+type Filter struct {
+	Column string
+	Type   string
+	Value  string
+	Values []string
+}
+
+// OrderBy describes a single "column order" clause applied to a list query.
+type OrderBy struct {
+	Column string
+	Order  string
+}
+
+// GetListFilter carries pagination, filtering and ordering for list endpoints.
+//
+// Pagination is either offset-based (Page/Limit) or cursor-based (Cursor/Limit); callers
+// set whichever pair applies and leave the other at its zero value.
+type GetListFilter struct {
+	Page    int
+	Limit   int
+	Cursor  string
+	Filters []Filter
+	Ranges  []Range
+	OrderBy []OrderBy
+}
+
+// Range is an inclusive [From, To] bound on a single column; either side may be left zero
+// to leave that end of the range open.
+type Range struct {
+	Column string
+	From   string
+	To     string
+}
+
+// SuccessResponse is the generic body returned for operations with no payload.
+type SuccessResponse struct {
+	Message string `json:"message"`
+}
+
+// ErrorResponse is the generic error body returned by the API.
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
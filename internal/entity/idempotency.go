@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// IdempotencyKey is a cached response for a prior request, keyed by hash(user_id, key).
+type IdempotencyKey struct {
+	KeyHash      string    `json:"-"`
+	RequestHash  string    `json:"-"`
+	StatusCode   int       `json:"-"`
+	ResponseBody []byte    `json:"-"`
+	ExpiresAt    time.Time `json:"-"`
+}
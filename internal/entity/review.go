@@ -0,0 +1,63 @@
+package entity
+
+import "time"
+
+// ReviewStatus is the moderation state of a review.
+type ReviewStatus string
+
+const (
+	ReviewStatusPending  ReviewStatus = "pending"
+	ReviewStatusApproved ReviewStatus = "approved"
+	ReviewStatusRejected ReviewStatus = "rejected"
+	ReviewStatusFlagged  ReviewStatus = "flagged"
+	ReviewStatusHidden   ReviewStatus = "hidden"
+)
+
+type Review struct {
+	ID         string       `json:"id"`
+	UserID     string       `json:"user_id"`
+	BusinessID string       `json:"business_id"`
+	Rating     float64      `json:"rating"`
+	Comment    string       `json:"comment"`
+	Photos     string       `json:"photos"`
+	Status     ReviewStatus `json:"status"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+
+	// CommentsCount is only populated when GetReview is called with ?include=comments_count.
+	CommentsCount *int `json:"comments_count,omitempty"`
+}
+
+type ReviewList struct {
+	Reviews []Review `json:"reviews"`
+	Count   int      `json:"count"`
+
+	// NextCursor is only populated when the list was fetched with cursor pagination.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ReviewExportColumns are the columns GetReviews' CSV export writes, in order.
+var ReviewExportColumns = []string{
+	"id", "user_id", "business_id", "rating", "comment", "status", "created_at",
+}
+
+// ModerationAction is the request body for the approve/reject/flag endpoints.
+type ModerationAction struct {
+	Reason string `json:"reason"`
+}
+
+// ReviewModerationEvent is a single audited status transition of a review.
+type ReviewModerationEvent struct {
+	ID         string       `json:"id"`
+	ReviewID   string       `json:"review_id"`
+	ReviewerID string       `json:"reviewer_id"`
+	FromStatus ReviewStatus `json:"from_status"`
+	ToStatus   ReviewStatus `json:"to_status"`
+	Reason     string       `json:"reason"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+type ReviewModerationHistory struct {
+	Events []ReviewModerationEvent `json:"events"`
+	Count  int                     `json:"count"`
+}
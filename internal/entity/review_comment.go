@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// ReviewComment is a single comment (or reply, via ParentID) on a review's discussion thread.
+type ReviewComment struct {
+	ID        string    `json:"id"`
+	ReviewID  string    `json:"review_id"`
+	UserID    string    `json:"user_id"`
+	ParentID  *string   `json:"parent_id,omitempty"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ReviewCommentList struct {
+	Comments []ReviewComment `json:"comments"`
+	Count    int             `json:"count"`
+}
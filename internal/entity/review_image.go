@@ -0,0 +1,42 @@
+package entity
+
+import "time"
+
+type ReviewImageStatus string
+
+const (
+	ReviewImageStatusUploading ReviewImageStatus = "uploading"
+	ReviewImageStatusReady     ReviewImageStatus = "ready"
+	ReviewImageStatusRejected  ReviewImageStatus = "rejected"
+)
+
+// ReviewImageVariant is one resized rendition of an uploaded review image.
+type ReviewImageVariant string
+
+const (
+	ReviewImageVariantThumbnail ReviewImageVariant = "thumbnail"
+	ReviewImageVariantMedium    ReviewImageVariant = "medium"
+	ReviewImageVariantLarge     ReviewImageVariant = "large"
+)
+
+// ReviewImage is a single stored object key (one variant) belonging to a review's image job.
+type ReviewImage struct {
+	ID        string             `json:"id"`
+	ReviewID  string             `json:"review_id"`
+	JobID     string             `json:"job_id"`
+	Variant   ReviewImageVariant `json:"variant"`
+	ObjectKey string             `json:"object_key"`
+	URL       string             `json:"url,omitempty"`
+	Status    ReviewImageStatus  `json:"status"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+type ReviewImageList struct {
+	Images []ReviewImage `json:"images"`
+}
+
+// ReviewImageJobAccepted is returned from SetReviewImage once the upload has been validated
+// and handed off to the background pipeline.
+type ReviewImageJobAccepted struct {
+	JobID string `json:"job_id"`
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Avazbek-02/udevslab-lesson6/internal/entity"
+)
+
+// IdempotencyRepo is the postgres-backed implementation of usecase.IdempotencyRepo.
+type IdempotencyRepo struct {
+	DB *sql.DB
+}
+
+func NewIdempotencyRepo(db *sql.DB) *IdempotencyRepo {
+	return &IdempotencyRepo{DB: db}
+}
+
+// Reserve atomically claims a key_hash for the caller. It reports false if the key was
+// already claimed by a concurrent/earlier request whose reservation hasn't expired yet; once
+// expires_at has passed — whether that request crashed mid-flight or simply finished and its
+// TTL ran out — the row is reclaimed and reset to a fresh in-flight state.
+func (r *IdempotencyRepo) Reserve(ctx context.Context, key entity.IdempotencyKey) (bool, error) {
+	res, err := r.DB.ExecContext(ctx, `
+		INSERT INTO idempotency_keys (key_hash, request_hash, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (key_hash) DO UPDATE SET
+			request_hash = EXCLUDED.request_hash,
+			status_code = 0,
+			response_body = ''::bytea,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < now()
+	`, key.KeyHash, key.RequestHash, key.ExpiresAt)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *IdempotencyRepo) Get(ctx context.Context, keyHash string) (entity.IdempotencyKey, bool, error) {
+	var key entity.IdempotencyKey
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT key_hash, request_hash, status_code, response_body, expires_at
+		FROM idempotency_keys
+		WHERE key_hash = $1 AND expires_at > now()
+	`, keyHash).Scan(&key.KeyHash, &key.RequestHash, &key.StatusCode, &key.ResponseBody, &key.ExpiresAt)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.IdempotencyKey{}, false, nil
+	}
+	if err != nil {
+		return entity.IdempotencyKey{}, false, err
+	}
+
+	return key, true, nil
+}
+
+// Complete fills in the response for a key previously claimed with Reserve.
+func (r *IdempotencyRepo) Complete(ctx context.Context, keyHash string, statusCode int, responseBody []byte) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE idempotency_keys SET status_code = $2, response_body = $3 WHERE key_hash = $1
+	`, keyHash, statusCode, responseBody)
+
+	return err
+}
@@ -0,0 +1,265 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Avazbek-02/udevslab-lesson6/internal/entity"
+)
+
+// ReviewRepo is the postgres-backed implementation of usecase.ReviewRepo.
+type ReviewRepo struct {
+	DB *sql.DB
+}
+
+func NewReviewRepo(db *sql.DB) *ReviewRepo {
+	return &ReviewRepo{DB: db}
+}
+
+func (r *ReviewRepo) Create(ctx context.Context, review entity.Review) (entity.Review, error) {
+	if review.Status == "" {
+		review.Status = entity.ReviewStatusPending
+	}
+
+	query := `
+		INSERT INTO reviews (user_id, business_id, rating, comment, photos, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, user_id, business_id, rating, comment, photos, status, created_at, updated_at
+	`
+
+	row := r.DB.QueryRowContext(ctx, query,
+		review.UserID, review.BusinessID, review.Rating, review.Comment, review.Photos, review.Status,
+	)
+
+	var res entity.Review
+	if err := row.Scan(
+		&res.ID, &res.UserID, &res.BusinessID, &res.Rating, &res.Comment, &res.Photos, &res.Status,
+		&res.CreatedAt, &res.UpdatedAt,
+	); err != nil {
+		return entity.Review{}, err
+	}
+
+	return res, nil
+}
+
+func (r *ReviewRepo) GetSingle(ctx context.Context, id entity.Id) (entity.Review, error) {
+	query := `
+		SELECT id, user_id, business_id, rating, comment, photos, status, created_at, updated_at
+		FROM reviews
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	var res entity.Review
+	err := r.DB.QueryRowContext(ctx, query, id.ID).Scan(
+		&res.ID, &res.UserID, &res.BusinessID, &res.Rating, &res.Comment, &res.Photos, &res.Status,
+		&res.CreatedAt, &res.UpdatedAt,
+	)
+	if err != nil {
+		return entity.Review{}, err
+	}
+
+	return res, nil
+}
+
+func (r *ReviewRepo) GetList(ctx context.Context, filter entity.GetListFilter) (entity.ReviewList, error) {
+	where := "WHERE deleted_at IS NULL"
+	args := []interface{}{}
+
+	for _, f := range filter.Filters {
+		if f.Type == "in" {
+			if len(f.Values) == 0 {
+				continue
+			}
+			placeholders := make([]string, len(f.Values))
+			for i, v := range f.Values {
+				args = append(args, v)
+				placeholders[i] = fmt.Sprintf("$%d", len(args))
+			}
+			where += fmt.Sprintf(" AND %s IN (%s)", f.Column, strings.Join(placeholders, ","))
+			continue
+		}
+
+		if f.Value == "" {
+			continue
+		}
+		args = append(args, f.Value)
+		if f.Type == "ilike" {
+			where += fmt.Sprintf(" AND %s ILIKE '%%' || $%d || '%%'", f.Column, len(args))
+			continue
+		}
+		where += fmt.Sprintf(" AND %s = $%d", f.Column, len(args))
+	}
+
+	for _, rg := range filter.Ranges {
+		if rg.From != "" {
+			args = append(args, rg.From)
+			where += fmt.Sprintf(" AND %s >= $%d", rg.Column, len(args))
+		}
+		if rg.To != "" {
+			args = append(args, rg.To)
+			where += fmt.Sprintf(" AND %s <= $%d", rg.Column, len(args))
+		}
+	}
+
+	// Cursor pagination is a keyset seek on created_at, so it needs a stable, known order.
+	if filter.Cursor != "" {
+		args = append(args, filter.Cursor)
+		where += fmt.Sprintf(" AND created_at < $%d", len(args))
+	}
+
+	order := "ORDER BY created_at DESC"
+	if len(filter.OrderBy) > 0 {
+		order = fmt.Sprintf("ORDER BY %s %s", filter.OrderBy[0].Column, filter.OrderBy[0].Order)
+	}
+
+	limitClause := ""
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		limitClause = fmt.Sprintf(" LIMIT $%d", len(args))
+		if filter.Cursor == "" {
+			args = append(args, (filter.Page-1)*filter.Limit)
+			limitClause += fmt.Sprintf(" OFFSET $%d", len(args))
+		}
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, user_id, business_id, rating, comment, photos, status, created_at, updated_at
+		FROM reviews
+		%s
+		%s
+		%s
+	`, where, order, limitClause)
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return entity.ReviewList{}, err
+	}
+	defer rows.Close()
+
+	var res entity.ReviewList
+	for rows.Next() {
+		var rev entity.Review
+		if err := rows.Scan(
+			&rev.ID, &rev.UserID, &rev.BusinessID, &rev.Rating, &rev.Comment, &rev.Photos, &rev.Status,
+			&rev.CreatedAt, &rev.UpdatedAt,
+		); err != nil {
+			return entity.ReviewList{}, err
+		}
+		res.Reviews = append(res.Reviews, rev)
+	}
+	res.Count = len(res.Reviews)
+
+	if filter.Cursor != "" && len(res.Reviews) == filter.Limit {
+		res.NextCursor = res.Reviews[len(res.Reviews)-1].CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	return res, rows.Err()
+}
+
+func (r *ReviewRepo) Update(ctx context.Context, review entity.Review) (entity.Review, error) {
+	query := `
+		UPDATE reviews
+		SET rating = COALESCE(NULLIF($2, 0), rating),
+			comment = COALESCE(NULLIF($3, ''), comment),
+			photos = COALESCE(NULLIF($4, ''), photos),
+			updated_at = now()
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING id, user_id, business_id, rating, comment, photos, status, created_at, updated_at
+	`
+
+	var res entity.Review
+	err := r.DB.QueryRowContext(ctx, query, review.ID, review.Rating, review.Comment, review.Photos).Scan(
+		&res.ID, &res.UserID, &res.BusinessID, &res.Rating, &res.Comment, &res.Photos, &res.Status,
+		&res.CreatedAt, &res.UpdatedAt,
+	)
+	if err != nil {
+		return entity.Review{}, err
+	}
+
+	return res, nil
+}
+
+func (r *ReviewRepo) Delete(ctx context.Context, id entity.Id) error {
+	query := `UPDATE reviews SET deleted_at = now() WHERE id = $1`
+
+	_, err := r.DB.ExecContext(ctx, query, id.ID)
+	return err
+}
+
+// Transition updates the review's status and records the change in review_moderation_events
+// inside a single transaction.
+func (r *ReviewRepo) Transition(ctx context.Context, event entity.ReviewModerationEvent) (entity.Review, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return entity.Review{}, err
+	}
+	defer tx.Rollback()
+
+	var review entity.Review
+	err = tx.QueryRowContext(ctx, `
+		SELECT id, user_id, business_id, rating, comment, photos, status, created_at, updated_at
+		FROM reviews
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`, event.ReviewID).Scan(
+		&review.ID, &review.UserID, &review.BusinessID, &review.Rating, &review.Comment, &review.Photos,
+		&review.Status, &review.CreatedAt, &review.UpdatedAt,
+	)
+	if err != nil {
+		return entity.Review{}, err
+	}
+
+	event.FromStatus = review.Status
+
+	err = tx.QueryRowContext(ctx, `
+		UPDATE reviews SET status = $2, updated_at = now() WHERE id = $1
+		RETURNING status, updated_at
+	`, event.ReviewID, event.ToStatus).Scan(&review.Status, &review.UpdatedAt)
+	if err != nil {
+		return entity.Review{}, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO review_moderation_events (review_id, reviewer_id, from_status, to_status, reason)
+		VALUES ($1, $2, $3, $4, $5)
+	`, event.ReviewID, event.ReviewerID, event.FromStatus, event.ToStatus, event.Reason)
+	if err != nil {
+		return entity.Review{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return entity.Review{}, err
+	}
+
+	return review, nil
+}
+
+func (r *ReviewRepo) GetHistory(ctx context.Context, reviewID string) (entity.ReviewModerationHistory, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT id, review_id, reviewer_id, from_status, to_status, reason, created_at
+		FROM review_moderation_events
+		WHERE review_id = $1
+		ORDER BY created_at DESC
+	`, reviewID)
+	if err != nil {
+		return entity.ReviewModerationHistory{}, err
+	}
+	defer rows.Close()
+
+	var res entity.ReviewModerationHistory
+	for rows.Next() {
+		var ev entity.ReviewModerationEvent
+		if err := rows.Scan(
+			&ev.ID, &ev.ReviewID, &ev.ReviewerID, &ev.FromStatus, &ev.ToStatus, &ev.Reason, &ev.CreatedAt,
+		); err != nil {
+			return entity.ReviewModerationHistory{}, err
+		}
+		res.Events = append(res.Events, ev)
+	}
+	res.Count = len(res.Events)
+
+	return res, rows.Err()
+}
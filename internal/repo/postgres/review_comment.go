@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Avazbek-02/udevslab-lesson6/internal/entity"
+)
+
+// ReviewCommentRepo is the postgres-backed implementation of usecase.ReviewCommentRepo.
+type ReviewCommentRepo struct {
+	DB *sql.DB
+}
+
+func NewReviewCommentRepo(db *sql.DB) *ReviewCommentRepo {
+	return &ReviewCommentRepo{DB: db}
+}
+
+func (r *ReviewCommentRepo) Create(ctx context.Context, comment entity.ReviewComment) (entity.ReviewComment, error) {
+	query := `
+		INSERT INTO review_comments (review_id, user_id, parent_id, text)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, review_id, user_id, parent_id, text, created_at, updated_at
+	`
+
+	row := r.DB.QueryRowContext(ctx, query, comment.ReviewID, comment.UserID, comment.ParentID, comment.Text)
+
+	var res entity.ReviewComment
+	if err := row.Scan(
+		&res.ID, &res.ReviewID, &res.UserID, &res.ParentID, &res.Text, &res.CreatedAt, &res.UpdatedAt,
+	); err != nil {
+		return entity.ReviewComment{}, err
+	}
+
+	return res, nil
+}
+
+func (r *ReviewCommentRepo) Reply(ctx context.Context, parentID string, comment entity.ReviewComment) (entity.ReviewComment, error) {
+	comment.ParentID = &parentID
+	return r.Create(ctx, comment)
+}
+
+func (r *ReviewCommentRepo) GetList(ctx context.Context, reviewID string, filter entity.GetListFilter) (entity.ReviewCommentList, error) {
+	if filter.Limit == 0 {
+		filter.Limit = 10
+	}
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+
+	query := `
+		SELECT id, review_id, user_id, parent_id, text, created_at, updated_at
+		FROM review_comments
+		WHERE review_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.DB.QueryContext(ctx, query, reviewID, filter.Limit, (filter.Page-1)*filter.Limit)
+	if err != nil {
+		return entity.ReviewCommentList{}, err
+	}
+	defer rows.Close()
+
+	var res entity.ReviewCommentList
+	for rows.Next() {
+		var c entity.ReviewComment
+		if err := rows.Scan(&c.ID, &c.ReviewID, &c.UserID, &c.ParentID, &c.Text, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return entity.ReviewCommentList{}, err
+		}
+		res.Comments = append(res.Comments, c)
+	}
+	res.Count = len(res.Comments)
+
+	return res, rows.Err()
+}
+
+func (r *ReviewCommentRepo) Delete(ctx context.Context, id entity.Id) error {
+	_, err := r.DB.ExecContext(ctx, `UPDATE review_comments SET deleted_at = now() WHERE id = $1`, id.ID)
+	return err
+}
+
+func (r *ReviewCommentRepo) CountByReview(ctx context.Context, reviewID string) (int, error) {
+	var count int
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT count(*) FROM review_comments WHERE review_id = $1 AND deleted_at IS NULL
+	`, reviewID).Scan(&count)
+
+	return count, err
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Avazbek-02/udevslab-lesson6/internal/entity"
+)
+
+// ReviewImageRepo is the postgres-backed implementation of usecase.ReviewImageRepo.
+type ReviewImageRepo struct {
+	DB *sql.DB
+}
+
+func NewReviewImageRepo(db *sql.DB) *ReviewImageRepo {
+	return &ReviewImageRepo{DB: db}
+}
+
+func (r *ReviewImageRepo) CreateVariant(ctx context.Context, img entity.ReviewImage) error {
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO review_images (id, review_id, job_id, variant, object_key, status)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5)
+	`, img.ReviewID, img.JobID, img.Variant, img.ObjectKey, img.Status)
+
+	return err
+}
+
+func (r *ReviewImageRepo) UpdateStatus(ctx context.Context, jobID string, variant entity.ReviewImageVariant, status entity.ReviewImageStatus, objectKey string) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE review_images SET status = $3, object_key = $4
+		WHERE job_id = $1 AND variant = $2
+	`, jobID, variant, status, objectKey)
+
+	return err
+}
+
+func (r *ReviewImageRepo) RejectJob(ctx context.Context, jobID string) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE review_images SET status = $2 WHERE job_id = $1
+	`, jobID, entity.ReviewImageStatusRejected)
+
+	return err
+}
+
+func (r *ReviewImageRepo) GetByReview(ctx context.Context, reviewID string) (entity.ReviewImageList, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT id, review_id, job_id, variant, object_key, status, created_at
+		FROM review_images
+		WHERE review_id = $1
+		ORDER BY created_at ASC
+	`, reviewID)
+	if err != nil {
+		return entity.ReviewImageList{}, err
+	}
+	defer rows.Close()
+
+	var res entity.ReviewImageList
+	for rows.Next() {
+		var img entity.ReviewImage
+		if err := rows.Scan(&img.ID, &img.ReviewID, &img.JobID, &img.Variant, &img.ObjectKey, &img.Status, &img.CreatedAt); err != nil {
+			return entity.ReviewImageList{}, err
+		}
+		res.Images = append(res.Images, img)
+	}
+
+	return res, rows.Err()
+}
@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Avazbek-02/udevslab-lesson6/internal/entity"
+)
+
+// IdempotencyRepo stores replayable responses keyed by hash(user_id, Idempotency-Key).
+type IdempotencyRepo interface {
+	// Reserve atomically claims keyHash for the caller, reporting false if it was already
+	// claimed by an earlier or concurrent request whose reservation hasn't expired yet. An
+	// expired reservation (crashed mid-flight, or simply past its TTL) is reclaimed instead
+	// of permanently wedging the key.
+	Reserve(ctx context.Context, key entity.IdempotencyKey) (reserved bool, err error)
+	Get(ctx context.Context, keyHash string) (entity.IdempotencyKey, bool, error)
+	// Complete fills in the response for a key previously claimed with Reserve, whatever its
+	// status code — an error response must replay too, or a retry would redo the side effects.
+	Complete(ctx context.Context, keyHash string, statusCode int, responseBody []byte) error
+}
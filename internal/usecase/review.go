@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Avazbek-02/udevslab-lesson6/internal/entity"
+)
+
+// ReviewRepo is the persistence port consumed by the review handlers.
+type ReviewRepo interface {
+	Create(ctx context.Context, review entity.Review) (entity.Review, error)
+	GetSingle(ctx context.Context, id entity.Id) (entity.Review, error)
+	GetList(ctx context.Context, filter entity.GetListFilter) (entity.ReviewList, error)
+	Update(ctx context.Context, review entity.Review) (entity.Review, error)
+	Delete(ctx context.Context, id entity.Id) error
+
+	// Transition records a moderation status change and its audit event atomically.
+	Transition(ctx context.Context, event entity.ReviewModerationEvent) (entity.Review, error)
+	GetHistory(ctx context.Context, reviewID string) (entity.ReviewModerationHistory, error)
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Avazbek-02/udevslab-lesson6/internal/entity"
+	"github.com/Avazbek-02/udevslab-lesson6/pkg/ctxcache"
+)
+
+// reviewCacheType namespaces review entries within the per-request ctxcache.
+const reviewCacheType = "review"
+
+// CachedReviewRepo wraps a ReviewRepo and serves GetSingle out of the request-scoped
+// ctxcache when the same review has already been fetched once in this request. GetList isn't
+// wrapped: its result depends on the full entity.GetListFilter, not a single id, so there's no
+// repeat-lookup to dedupe. See app.NewUseCase for where this gets plugged into UseCase.ReviewRepo.
+type CachedReviewRepo struct {
+	ReviewRepo
+}
+
+func NewCachedReviewRepo(repo ReviewRepo) *CachedReviewRepo {
+	return &CachedReviewRepo{ReviewRepo: repo}
+}
+
+func (r *CachedReviewRepo) GetSingle(ctx context.Context, id entity.Id) (entity.Review, error) {
+	if cached, ok := ctxcache.GetContextData(ctx, reviewCacheType, id.ID); ok {
+		return cached.(entity.Review), nil
+	}
+
+	review, err := r.ReviewRepo.GetSingle(ctx, id)
+	if err != nil {
+		return entity.Review{}, err
+	}
+
+	ctxcache.SetContextData(ctx, reviewCacheType, id.ID, review)
+	return review, nil
+}
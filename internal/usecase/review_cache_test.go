@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Avazbek-02/udevslab-lesson6/internal/entity"
+	"github.com/Avazbek-02/udevslab-lesson6/pkg/ctxcache"
+)
+
+// countingReviewRepo is a bare-bones ReviewRepo that only tracks how many times
+// GetSingle reaches the "database".
+type countingReviewRepo struct {
+	ReviewRepo
+	getSingleCalls int
+}
+
+func (r *countingReviewRepo) GetSingle(ctx context.Context, id entity.Id) (entity.Review, error) {
+	r.getSingleCalls++
+	return entity.Review{ID: id.ID}, nil
+}
+
+func TestCachedReviewRepo_GetSingle_DedupesWithinOneRequest(t *testing.T) {
+	inner := &countingReviewRepo{}
+	repo := NewCachedReviewRepo(inner)
+
+	ctx := ctxcache.WithCacheContext(context.Background())
+
+	if _, err := repo.GetSingle(ctx, entity.Id{ID: "1"}); err != nil {
+		t.Fatalf("first GetSingle: %v", err)
+	}
+	if _, err := repo.GetSingle(ctx, entity.Id{ID: "1"}); err != nil {
+		t.Fatalf("second GetSingle: %v", err)
+	}
+
+	if inner.getSingleCalls != 1 {
+		t.Fatalf("got %d DB calls, want 1", inner.getSingleCalls)
+	}
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Avazbek-02/udevslab-lesson6/internal/entity"
+)
+
+// ReviewCommentRepo is the persistence port for a review's discussion thread.
+type ReviewCommentRepo interface {
+	Create(ctx context.Context, comment entity.ReviewComment) (entity.ReviewComment, error)
+	GetList(ctx context.Context, reviewID string, filter entity.GetListFilter) (entity.ReviewCommentList, error)
+	Reply(ctx context.Context, parentID string, comment entity.ReviewComment) (entity.ReviewComment, error)
+	Delete(ctx context.Context, id entity.Id) error
+	CountByReview(ctx context.Context, reviewID string) (int, error)
+}
@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Avazbek-02/udevslab-lesson6/internal/entity"
+)
+
+// ReviewImageRepo is the persistence port for a review's uploaded image variants.
+type ReviewImageRepo interface {
+	CreateVariant(ctx context.Context, img entity.ReviewImage) error
+	UpdateStatus(ctx context.Context, jobID string, variant entity.ReviewImageVariant, status entity.ReviewImageStatus, objectKey string) error
+	RejectJob(ctx context.Context, jobID string) error
+	GetByReview(ctx context.Context, reviewID string) (entity.ReviewImageList, error)
+}
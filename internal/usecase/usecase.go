@@ -0,0 +1,9 @@
+package usecase
+
+// UseCase aggregates every repository the handlers depend on.
+type UseCase struct {
+	ReviewRepo        ReviewRepo
+	ReviewCommentRepo ReviewCommentRepo
+	ReviewImageRepo   ReviewImageRepo
+	IdempotencyRepo   IdempotencyRepo
+}
@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// ClamAVScanner talks to clamd's TCP socket using the INSTREAM protocol.
+type ClamAVScanner struct {
+	Addr    string
+	Timeout time.Duration
+}
+
+func NewClamAVScanner(addr string) *ClamAVScanner {
+	return &ClamAVScanner{Addr: addr, Timeout: 10 * time.Second}
+}
+
+// ScanClean reports true if clamd replies "stream: OK".
+func (s *ClamAVScanner) ScanClean(ctx context.Context, data []byte) (bool, error) {
+	dialer := net.Dialer{Timeout: s.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.Addr)
+	if err != nil {
+		return false, fmt.Errorf("dial clamd: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return false, fmt.Errorf("write clamd command: %w", err)
+	}
+
+	chunkSize := make([]byte, 4)
+	for offset := 0; offset < len(data); offset += 1 << 20 {
+		end := offset + (1 << 20)
+		if end > len(data) {
+			end = len(data)
+		}
+		chunk := data[offset:end]
+
+		putUint32BE(chunkSize, uint32(len(chunk)))
+		if _, err := conn.Write(chunkSize); err != nil {
+			return false, fmt.Errorf("write chunk size: %w", err)
+		}
+		if _, err := conn.Write(chunk); err != nil {
+			return false, fmt.Errorf("write chunk: %w", err)
+		}
+	}
+
+	putUint32BE(chunkSize, 0)
+	if _, err := conn.Write(chunkSize); err != nil {
+		return false, fmt.Errorf("write terminator: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString('\x00')
+	if err != nil {
+		return false, fmt.Errorf("read clamd reply: %w", err)
+	}
+
+	return reply == "stream: OK\x00", nil
+}
+
+func putUint32BE(b []byte, v uint32) {
+	b[0] = byte(v >> 24)
+	b[1] = byte(v >> 16)
+	b[2] = byte(v >> 8)
+	b[3] = byte(v)
+}
@@ -0,0 +1,153 @@
+// Package worker runs the background jobs the handler layer enqueues instead of doing the
+// work inline on the request goroutine.
+package worker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif" // register the GIF decoder used by image.Decode below
+	"image/jpeg"
+	_ "image/png" // register the PNG decoder used by image.Decode below
+	"log"
+
+	"golang.org/x/image/draw"
+
+	"github.com/Avazbek-02/udevslab-lesson6/internal/entity"
+)
+
+// ImageJob is one uploaded file waiting to be scanned, resized, and published.
+type ImageJob struct {
+	JobID    string
+	ReviewID string
+	Raw      []byte
+}
+
+// SupportedMIMETypes are the content types image.Decode can actually handle with the formats
+// registered above. The handler's upload validation must stay in lockstep with this set.
+var SupportedMIMETypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// variantSizes are the max width, in pixels, of each rendition the pipeline produces; height
+// is scaled to preserve the source aspect ratio.
+var variantSizes = map[entity.ReviewImageVariant]int{
+	entity.ReviewImageVariantThumbnail: 200,
+	entity.ReviewImageVariantMedium:    800,
+	entity.ReviewImageVariantLarge:     1600,
+}
+
+// Uploader stores a rendered variant and returns its object key.
+type Uploader interface {
+	UploadBytes(filename string, data []byte) (objectKey string, err error)
+}
+
+// Scanner reports whether a file is clean. It is optional: a nil Scanner skips scanning.
+type Scanner interface {
+	ScanClean(ctx context.Context, data []byte) (bool, error)
+}
+
+// Store persists per-variant rows and updates their status as the pipeline progresses.
+type Store interface {
+	CreateVariant(ctx context.Context, img entity.ReviewImage) error
+	UpdateStatus(ctx context.Context, jobID string, variant entity.ReviewImageVariant, status entity.ReviewImageStatus, objectKey string) error
+	RejectJob(ctx context.Context, jobID string) error
+}
+
+// ImagePipeline processes ImageJobs pulled off an in-memory channel. Only NewImagePipeline,
+// Enqueue and Run touch the channel.
+type ImagePipeline struct {
+	jobs     chan ImageJob
+	uploader Uploader
+	scanner  Scanner
+	store    Store
+}
+
+func NewImagePipeline(uploader Uploader, scanner Scanner, store Store) *ImagePipeline {
+	return &ImagePipeline{
+		jobs:     make(chan ImageJob, 64),
+		uploader: uploader,
+		scanner:  scanner,
+		store:    store,
+	}
+}
+
+// Enqueue hands a job to the pipeline without waiting for it to be processed. It blocks while
+// the channel's 64-job buffer is full.
+func (p *ImagePipeline) Enqueue(job ImageJob) {
+	p.jobs <- job
+}
+
+// Run processes jobs until ctx is cancelled. Call it from a long-lived goroutine at startup.
+func (p *ImagePipeline) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-p.jobs:
+			p.process(ctx, job)
+		}
+	}
+}
+
+func (p *ImagePipeline) process(ctx context.Context, job ImageJob) {
+	if p.scanner != nil {
+		clean, err := p.scanner.ScanClean(ctx, job.Raw)
+		if err != nil || !clean {
+			if err != nil {
+				log.Printf("worker: clamav scan failed for job %s: %v", job.JobID, err)
+			}
+			if rejErr := p.store.RejectJob(ctx, job.JobID); rejErr != nil {
+				log.Printf("worker: failed to mark job %s rejected: %v", job.JobID, rejErr)
+			}
+			return
+		}
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(job.Raw))
+	if err != nil {
+		log.Printf("worker: failed to decode job %s: %v", job.JobID, err)
+		_ = p.store.RejectJob(ctx, job.JobID)
+		return
+	}
+
+	for variant, maxWidth := range variantSizes {
+		data, err := resizeJPEG(src, maxWidth)
+		if err != nil {
+			log.Printf("worker: failed to render %s variant for job %s: %v", variant, job.JobID, err)
+			continue
+		}
+
+		objectKey, err := p.uploader.UploadBytes(fmt.Sprintf("%s-%s.jpg", job.JobID, variant), data)
+		if err != nil {
+			log.Printf("worker: failed to upload %s variant for job %s: %v", variant, job.JobID, err)
+			continue
+		}
+
+		if err := p.store.UpdateStatus(ctx, job.JobID, variant, entity.ReviewImageStatusReady, objectKey); err != nil {
+			log.Printf("worker: failed to persist %s variant for job %s: %v", variant, job.JobID, err)
+		}
+	}
+}
+
+func resizeJPEG(src image.Image, maxWidth int) ([]byte, error) {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > maxWidth {
+		height = height * maxWidth / width
+		width = maxWidth
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
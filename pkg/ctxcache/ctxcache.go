@@ -0,0 +1,70 @@
+// Package ctxcache provides a per-request cache keyed by an arbitrary "type" and "key" pair,
+// so that repeated lookups of the same entity within one HTTP request hit the database once.
+package ctxcache
+
+import (
+	"context"
+	"sync"
+)
+
+type cacheKey struct{}
+
+type store struct {
+	mu   sync.RWMutex
+	data map[any]map[any]any
+}
+
+// WithCacheContext returns a derived context carrying a fresh, empty cache. Calling it twice
+// on the same context installs two independent caches; only the innermost one is visible.
+func WithCacheContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheKey{}, &store{data: make(map[any]map[any]any)})
+}
+
+// GetContextData looks up the value stored for (tp, key). The bool reports whether it was
+// found; it is always false if ctx has no cache installed.
+func GetContextData(ctx context.Context, tp, key any) (any, bool) {
+	s, ok := ctx.Value(cacheKey{}).(*store)
+	if !ok {
+		return nil, false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	bucket, ok := s.data[tp]
+	if !ok {
+		return nil, false
+	}
+
+	v, ok := bucket[key]
+	return v, ok
+}
+
+// SetContextData stores value under (tp, key). It is a no-op if ctx has no cache installed.
+func SetContextData(ctx context.Context, tp, key, value any) {
+	s, ok := ctx.Value(cacheKey{}).(*store)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.data[tp] == nil {
+		s.data[tp] = make(map[any]any)
+	}
+	s.data[tp][key] = value
+}
+
+// RemoveContextData deletes the value stored for (tp, key), if any.
+func RemoveContextData(ctx context.Context, tp, key any) {
+	s, ok := ctx.Value(cacheKey{}).(*store)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.data[tp], key)
+}
@@ -0,0 +1,35 @@
+package ctxcache
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetSetContextData(t *testing.T) {
+	ctx := WithCacheContext(context.Background())
+
+	if _, ok := GetContextData(ctx, "review", "1"); ok {
+		t.Fatal("expected cache miss before any Set")
+	}
+
+	SetContextData(ctx, "review", "1", "cached review")
+
+	v, ok := GetContextData(ctx, "review", "1")
+	if !ok {
+		t.Fatal("expected cache hit after Set")
+	}
+	if v != "cached review" {
+		t.Fatalf("got %v, want %q", v, "cached review")
+	}
+
+	RemoveContextData(ctx, "review", "1")
+	if _, ok := GetContextData(ctx, "review", "1"); ok {
+		t.Fatal("expected cache miss after Remove")
+	}
+}
+
+func TestGetContextData_NoCacheInstalled(t *testing.T) {
+	if _, ok := GetContextData(context.Background(), "review", "1"); ok {
+		t.Fatal("expected cache miss on a context with no cache installed")
+	}
+}